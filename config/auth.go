@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// AuthEnabled reports whether the server should require a bearer token on
+// every request, controlled by AUTH_ENABLED=true. Disabled by default so
+// local development doesn't need a token out of the box.
+func AuthEnabled() bool {
+	return os.Getenv("AUTH_ENABLED") == "true"
+}
+
+// AuthTokens returns the accepted bearer tokens from the comma-separated
+// AUTH_TOKENS env var. Used to build the default StaticTokenValidator; a
+// deployment that needs real token issuance/verification should supply its
+// own middleware.TokenValidator instead.
+func AuthTokens() []string {
+	raw := os.Getenv("AUTH_TOKENS")
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
@@ -0,0 +1,25 @@
+// Package config centralizes environment-driven application configuration.
+package config
+
+import "os"
+
+// Environment selects behavior that should differ between local
+// development and a real deployment, such as how much detail panic
+// responses expose to the client.
+type Environment string
+
+const (
+	Development Environment = "development"
+	Production  Environment = "production"
+)
+
+// EnvironmentFromEnv reads APP_ENV and returns the matching Environment,
+// defaulting to Production when the variable is unset or unrecognized so
+// that a misconfigured deployment fails closed rather than leaking stack
+// traces.
+func EnvironmentFromEnv() Environment {
+	if os.Getenv("APP_ENV") == string(Development) {
+		return Development
+	}
+	return Production
+}
@@ -0,0 +1,34 @@
+package lifecycle
+
+import "strings"
+
+// multiError aggregates the errors returned by multiple hooks so a single
+// failure doesn't stop the rest of shutdown from running, while still
+// surfacing every failure to the caller.
+type multiError struct {
+	errors []error
+}
+
+func (m *multiError) Append(err error) {
+	if err != nil {
+		m.errors = append(m.errors, err)
+	}
+}
+
+func (m *multiError) ErrorOrNil() error {
+	if len(m.errors) == 0 {
+		return nil
+	}
+	if len(m.errors) == 1 {
+		return m.errors[0]
+	}
+	return m
+}
+
+func (m *multiError) Error() string {
+	msgs := make([]string, len(m.errors))
+	for i, err := range m.errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,145 @@
+// Package lifecycle coordinates ordered, prioritized shutdown of
+// application subsystems behind a single context deadline.
+package lifecycle
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Phase groups hooks by when they run relative to connection draining.
+// PreShutdown hooks run first (e.g. stop accepting new background work),
+// then Shutdown hooks (tear down core resources), then PostShutdown hooks
+// (final logging/metrics flush).
+type Phase int
+
+const (
+	PreShutdown Phase = iota
+	Shutdown
+	PostShutdown
+)
+
+// HookFunc performs one unit of shutdown work. It should respect ctx's
+// deadline, which the Manager derives from the overall shutdown timeout.
+type HookFunc func(ctx context.Context) error
+
+// Logger receives one record per hook run, so operators can see which
+// subsystem shutdown spent its time on.
+type Logger interface {
+	Log(fields map[string]interface{})
+}
+
+type hook struct {
+	phase    Phase
+	name     string
+	priority int
+	fn       HookFunc
+}
+
+// Manager runs registered hooks in priority order, draining in-flight HTTP
+// requests before any hook executes.
+type Manager struct {
+	logger Logger
+	hooks  []hook
+}
+
+// NewManager returns a Manager that logs hook activity via logger. logger
+// may be nil to disable logging.
+func NewManager(logger Logger) *Manager {
+	return &Manager{logger: logger}
+}
+
+// Register adds fn to phase, running in ascending priority order relative
+// to other hooks in the same phase (lower priority values run first).
+func (m *Manager) Register(phase Phase, name string, priority int, fn HookFunc) {
+	m.hooks = append(m.hooks, hook{phase: phase, name: name, priority: priority, fn: fn})
+}
+
+// Shutdown drains server (if non-nil) via server.Shutdown, then runs every
+// registered hook across PreShutdown, Shutdown, and PostShutdown in
+// priority order. Each hook receives a context whose deadline is a share of
+// ctx's remaining deadline, split evenly across the hooks still left to
+// run, so one slow hook can't starve the rest of their time budget. Errors
+// from every step are aggregated rather than stopping the run early.
+func (m *Manager) Shutdown(ctx context.Context, server *http.Server) error {
+	var errs multiError
+
+	if server != nil {
+		if err := server.Shutdown(ctx); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	ordered := m.orderedHooks()
+	for i, h := range ordered {
+		remaining := len(ordered) - i
+		hookCtx, cancel := m.hookContext(ctx, remaining)
+
+		start := time.Now()
+		err := h.fn(hookCtx)
+		duration := time.Since(start)
+		cancel()
+
+		if m.logger != nil {
+			m.logger.Log(map[string]interface{}{
+				"hook":        h.name,
+				"phase":       phaseName(h.phase),
+				"duration_ms": duration.Milliseconds(),
+				"error":       errString(err),
+			})
+		}
+		errs.Append(err)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// orderedHooks returns hooks sorted by phase, then by priority within a
+// phase.
+func (m *Manager) orderedHooks() []hook {
+	ordered := make([]hook, len(m.hooks))
+	copy(ordered, m.hooks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].phase != ordered[j].phase {
+			return ordered[i].phase < ordered[j].phase
+		}
+		return ordered[i].priority < ordered[j].priority
+	})
+	return ordered
+}
+
+// hookContext derives a per-hook timeout from ctx's remaining deadline,
+// split evenly across the hooks that still need to run.
+func (m *Manager) hookContext(ctx context.Context, remainingHooks int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || remainingHooks <= 0 {
+		return context.WithCancel(ctx)
+	}
+	share := time.Until(deadline) / time.Duration(remainingHooks)
+	if share <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, share)
+}
+
+func phaseName(p Phase) string {
+	switch p {
+	case PreShutdown:
+		return "pre_shutdown"
+	case Shutdown:
+		return "shutdown"
+	case PostShutdown:
+		return "post_shutdown"
+	default:
+		return "unknown"
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
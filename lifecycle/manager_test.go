@@ -0,0 +1,90 @@
+package lifecycle
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManager_RunsHooksInPhaseThenPriorityOrder(t *testing.T) {
+	m := NewManager(nil)
+	var order []string
+	record := func(name string) HookFunc {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	// Registered out of order on purpose: Shutdown/Post before Pre, and
+	// descending priority within PreShutdown.
+	m.Register(Shutdown, "shutdown-main", 0, record("shutdown-main"))
+	m.Register(PostShutdown, "post", 0, record("post"))
+	m.Register(PreShutdown, "pre-low-priority", 10, record("pre-low-priority"))
+	m.Register(PreShutdown, "pre-high-priority", 0, record("pre-high-priority"))
+
+	if err := m.Shutdown(context.Background(), nil); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	want := []string{"pre-high-priority", "pre-low-priority", "shutdown-main", "post"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestManager_HookTimeoutDerivedFromOverallDeadline(t *testing.T) {
+	m := NewManager(nil)
+	var sawDeadline bool
+	var gotTimeout time.Duration
+
+	m.Register(Shutdown, "slow", 0, func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		sawDeadline = ok
+		if ok {
+			gotTimeout = time.Until(deadline)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := m.Shutdown(ctx, nil); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !sawDeadline {
+		t.Fatal("hook context had no deadline, want one derived from the overall timeout")
+	}
+	if gotTimeout <= 0 || gotTimeout > 100*time.Millisecond {
+		t.Fatalf("hook deadline = %v, want (0, 100ms]", gotTimeout)
+	}
+}
+
+func TestManager_AggregatesErrorsFromMultipleHooks(t *testing.T) {
+	m := NewManager(nil)
+	errA := errStr("hook a failed")
+	errB := errStr("hook b failed")
+
+	m.Register(Shutdown, "a", 0, func(ctx context.Context) error { return errA })
+	m.Register(Shutdown, "b", 1, func(ctx context.Context) error { return errB })
+
+	err := m.Shutdown(context.Background(), nil)
+	if err == nil {
+		t.Fatal("Shutdown() error = nil, want aggregated error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, errA.Error()) || !strings.Contains(msg, errB.Error()) {
+		t.Fatalf("aggregated error %q missing one of the hook errors", msg)
+	}
+}
+
+type errStr string
+
+func (e errStr) Error() string { return string(e) }
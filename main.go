@@ -11,31 +11,34 @@ import (
 	"runtime/pprof"
 	"syscall"
 	"time"
+
+	"github.com/Shishircipher/wecollabo/config"
+	"github.com/Shishircipher/wecollabo/lifecycle"
+	"github.com/Shishircipher/wecollabo/middleware"
+	"github.com/Shishircipher/wecollabo/notifier"
 )
 
 // Global logger (for demonstration, replace with structured logger like Zap or Logrus)
 var logger = log.New(os.Stdout, "INFO: ", log.LstdFlags|log.Lshortfile)
 
-// Panic recovery middleware
-func recoveryMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				var buf bytes.Buffer
-				pprof.Lookup("goroutine").WriteTo(&buf, 2) // Capture stack trace
-				logger.Printf("PANIC RECOVERED: %v\nSTACK TRACE:\n%s", err, buf.String())
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			}
-		}()
-		next.ServeHTTP(w, r)
-	})
+// jsonLogger adapts the stdlib logger to middleware.Logger by printing
+// fields as a flat JSON-ish line; swap this out for zerolog/zap in prod.
+type jsonLogger struct{}
+
+func (jsonLogger) Log(fields map[string]interface{}) {
+	logger.Printf("%v", fields)
 }
 
 // Simulated database cleanup
-func cleanup() {
+func cleanup(ctx context.Context) error {
 	logger.Println("Cleaning up resources (DB, caches, etc.)...")
-	time.Sleep(2 * time.Second) // Simulate cleanup time
+	select {
+	case <-time.After(2 * time.Second): // Simulate cleanup time
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	logger.Println("Cleanup complete. Server shutting down.")
+	return nil
 }
 
 func main() {
@@ -50,14 +53,42 @@ func main() {
 		panic("Something went wrong!") // Simulated panic
 	})
 
-	// Wrap middleware to recover from panics
-	handler := recoveryMiddleware(mux)
+	env := config.EnvironmentFromEnv()
+	logger.Printf("Running in %s mode", env)
+
+	// Compose the middleware chain: request ID first so every later layer
+	// (and the recovered panic log) can see it, then access logging so
+	// every request is recorded (including ones auth rejects), then auth
+	// (only if AUTH_ENABLED=true) to gate the handlers, then panic
+	// recovery innermost so it wraps the actual handlers.
+	constructors := []middleware.Constructor{
+		middleware.RequestIDMiddleware,
+		middleware.LoggingMiddleware(jsonLogger{}),
+	}
+	if config.AuthEnabled() {
+		validator := make(middleware.StaticTokenValidator)
+		for _, token := range config.AuthTokens() {
+			validator[token] = true
+		}
+		constructors = append(constructors, middleware.AuthMiddleware(validator))
+	}
+	constructors = append(constructors, middleware.Recovery(middleware.RecoveryOptions{
+		Logger:              jsonLogger{},
+		Environment:         env,
+		FilterRuntimeFrames: true,
+		Dispatcher:          notifier.LoadConfigFromEnv().Build(),
+	}))
+	chain := middleware.New(constructors...)
+	handler := chain.Then(mux)
 
 	server := &http.Server{
 		Addr:    ":8080",
 		Handler: handler,
 	}
 
+	mgr := lifecycle.NewManager(jsonLogger{})
+	mgr.Register(lifecycle.Shutdown, "cleanup-resources", 0, cleanup)
+
 	// Channel to listen for OS signals (Ctrl+C, kill command)
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
@@ -74,6 +105,16 @@ func main() {
 	<-stop // Wait for termination signal
 	logger.Println("Shutdown signal received. Cleaning up...")
 
+	// A second SIGINT means the operator wants out now, not after cleanup
+	// finishes, so force-kill the process rather than waiting.
+	forceKill := make(chan os.Signal, 1)
+	signal.Notify(forceKill, syscall.SIGINT)
+	go func() {
+		<-forceKill
+		logger.Println("Second interrupt received. Forcing immediate shutdown.")
+		os.Exit(1)
+	}()
+
 	// Set timeout for cleanup operations
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -87,13 +128,10 @@ func main() {
 		}
 	}()
 
-	// Perform cleanup (close DB, stop workers, etc.)
-	cleanup()
-
-	// Gracefully shutdown the server
-	if err := server.Shutdown(ctx); err != nil {
+	// Drain in-flight requests, then run every registered hook in priority
+	// order within its deadline share.
+	if err := mgr.Shutdown(ctx, server); err != nil {
 		logger.Fatalf("Server shutdown failed: %v", err)
 	}
 	logger.Println("Server exited gracefully.")
 }
-
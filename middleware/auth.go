@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// TokenValidator validates a bearer token extracted from the Authorization
+// header. Implementations might check a static token list, call out to an
+// OAuth introspection endpoint, or verify a signed JWT.
+type TokenValidator interface {
+	Validate(token string) bool
+}
+
+// AuthMiddleware returns a Constructor that rejects requests without a
+// valid "Authorization: Bearer <token>" header, checking the token against
+// the supplied TokenValidator.
+func AuthMiddleware(validator TokenValidator) Constructor {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const prefix = "Bearer "
+
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, prefix) {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			token := strings.TrimPrefix(header, prefix)
+			if token == "" || !validator.Validate(token) {
+				http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// StaticTokenValidator validates against a fixed set of accepted tokens. It
+// is mainly useful for local development and tests; production deployments
+// should supply a TokenValidator backed by a real identity provider.
+type StaticTokenValidator map[string]bool
+
+// Validate reports whether token is present in the set.
+func (v StaticTokenValidator) Validate(token string) bool {
+	return v[token]
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	validator := StaticTokenValidator{"good-token": true}
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantCalled bool
+	}{
+		{
+			name:       "missing header",
+			header:     "",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "malformed prefix",
+			header:     "Token good-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "empty token",
+			header:     "Bearer ",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			header:     "Bearer bad-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "valid token",
+			header:     "Bearer good-token",
+			wantStatus: http.StatusOK,
+			wantCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			handler := AuthMiddleware(validator)(next)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if called != tt.wantCalled {
+				t.Fatalf("next called = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+)
+
+// BufferedResponseWriter buffers headers, status, and body in memory
+// instead of writing them straight through to the underlying
+// http.ResponseWriter. This lets recoveryMiddleware discard a partial
+// response and send a clean error if the handler panics after it has
+// already started writing, instead of leaking a truncated 200.
+//
+// Handlers that need real-time delivery (SSE, streaming, HTTP/2 push) can
+// still opt out of buffering by calling Flush, Hijack, or Push directly;
+// see the method docs below.
+type BufferedResponseWriter struct {
+	underlying  http.ResponseWriter
+	header      http.Header
+	body        bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+	flushed     bool
+}
+
+// NewBufferedResponseWriter wraps w so writes are buffered until Flush,
+// FlushTo, Hijack, or Push is called.
+func NewBufferedResponseWriter(w http.ResponseWriter) *BufferedResponseWriter {
+	return &BufferedResponseWriter{
+		underlying: w,
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+}
+
+// Header returns the buffered header map. Mutating it before the first
+// Write or WriteHeader call behaves exactly like the standard
+// http.ResponseWriter. Once the response has been flushed (see Flush), the
+// headers already reached the client, so this returns the underlying
+// writer's header map instead (e.g. for trailers set after streaming has
+// started).
+func (b *BufferedResponseWriter) Header() http.Header {
+	if b.flushed {
+		return b.underlying.Header()
+	}
+	return b.header
+}
+
+// WriteHeader records the status code without forwarding it to the
+// underlying writer yet. Once the response has been flushed, headers have
+// already been sent and this is a no-op, matching the standard library's
+// handling of a redundant WriteHeader call.
+func (b *BufferedResponseWriter) WriteHeader(status int) {
+	if b.flushed || b.wroteHeader {
+		return
+	}
+	b.statusCode = status
+	b.wroteHeader = true
+}
+
+// Write buffers p in memory so it can still be discarded on panic. If no
+// status has been set yet it defaults to 200, matching http.ResponseWriter
+// semantics. Once the response has been flushed (see Flush), buffering
+// would silently swallow the rest of a streamed response, so writes go
+// straight through to the underlying writer instead.
+func (b *BufferedResponseWriter) Write(p []byte) (int, error) {
+	if b.flushed {
+		return b.underlying.Write(p)
+	}
+	if !b.wroteHeader {
+		b.WriteHeader(http.StatusOK)
+	}
+	return b.body.Write(p)
+}
+
+// FlushTo copies the buffered header, status, and body to the given
+// writer. Call it once the wrapped handler has returned without panicking.
+func (b *BufferedResponseWriter) FlushTo(w http.ResponseWriter) {
+	if b.flushed {
+		return
+	}
+	dst := w.Header()
+	for k, v := range b.header {
+		dst[k] = v
+	}
+	w.WriteHeader(b.statusCode)
+	w.Write(b.body.Bytes())
+}
+
+// Discard drops the buffered response so nothing reaches the client. Used
+// by recoveryMiddleware before it writes a fresh error response.
+func (b *BufferedResponseWriter) Discard() {
+	b.body.Reset()
+}
+
+// Flushed reports whether the response has already been sent to the
+// underlying writer, either via FlushTo or the Flusher interface below.
+func (b *BufferedResponseWriter) Flushed() bool {
+	return b.flushed
+}
+
+// Flush implements http.Flusher. Unlike a plain buffer, a real Flush call
+// means the handler is streaming and wants bytes on the wire now, so it
+// forwards the buffered header/status/body immediately and switches this
+// writer into passthrough mode for the rest of the request: a handler that
+// panics after this point has already sent bytes the client can see, so
+// there is nothing left to discard.
+func (b *BufferedResponseWriter) Flush() {
+	if !b.flushed {
+		b.FlushTo(b.underlying)
+		b.flushed = true
+	}
+	if f, ok := b.underlying.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, handing the caller the raw connection
+// so WebSocket upgrades work unmodified. Buffering is meaningless once the
+// connection is hijacked, so this bypasses it entirely.
+func (b *BufferedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := b.underlying.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	b.flushed = true
+	return hj.Hijack()
+}
+
+// Push implements http.Pusher so HTTP/2 server push keeps working for
+// handlers that use it.
+func (b *BufferedResponseWriter) Push(target string, opts *http.PushOptions) error {
+	p, ok := b.underlying.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return p.Push(target, opts)
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBufferedResponseWriter_SuccessFlushesBufferedBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	bw := NewBufferedResponseWriter(rec)
+
+	bw.Write([]byte("hello"))
+	bw.FlushTo(rec)
+
+	if got := rec.Body.String(); got != "hello" {
+		t.Fatalf("body = %q, want %q", got, "hello")
+	}
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+}
+
+func TestBufferedResponseWriter_DiscardDropsBufferedBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	bw := NewBufferedResponseWriter(rec)
+
+	bw.Write([]byte("partial before panic"))
+	bw.Discard()
+	rec.WriteHeader(500)
+	rec.Write([]byte("Internal Server Error"))
+
+	if got := rec.Body.String(); got != "Internal Server Error" {
+		t.Fatalf("body = %q, want clean 500 body", got)
+	}
+}
+
+// TestBufferedResponseWriter_StreamingAfterFlush reproduces the
+// write-then-flush streaming pattern (SSE, chunked responses): every byte
+// written after the first Flush must reach the underlying writer, not
+// accumulate in the discarded buffer.
+func TestBufferedResponseWriter_StreamingAfterFlush(t *testing.T) {
+	rec := httptest.NewRecorder()
+	bw := NewBufferedResponseWriter(rec)
+
+	for i := 1; i <= 3; i++ {
+		bw.Write([]byte("event" + string(rune('0'+i)) + "\n"))
+		bw.Flush()
+	}
+
+	want := "event1\nevent2\nevent3\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+// Package middleware provides alice-style composable HTTP middleware chains.
+package middleware
+
+import "net/http"
+
+// Constructor wraps an http.Handler with additional behavior.
+type Constructor func(http.Handler) http.Handler
+
+// Chain is an immutable, ordered list of Constructors. Constructors run in
+// the order they were added, outermost first, mirroring justinas/alice.
+type Chain struct {
+	constructors []Constructor
+}
+
+// New creates a Chain from the given Constructors.
+func New(constructors ...Constructor) Chain {
+	c := Chain{}
+	c.constructors = append(c.constructors, constructors...)
+	return c
+}
+
+// Then chains the Constructors and returns the final http.Handler.
+//
+//	middleware.New(RequestIDMiddleware, LoggingMiddleware(logger)).Then(mux)
+//
+// A nil handler defaults to http.DefaultServeMux, matching alice's behavior.
+func (c Chain) Then(h http.Handler) http.Handler {
+	if h == nil {
+		h = http.DefaultServeMux
+	}
+	for i := len(c.constructors) - 1; i >= 0; i-- {
+		h = c.constructors[i](h)
+	}
+	return h
+}
+
+// ThenFunc is a convenience wrapper for Then that takes a plain handler func.
+func (c Chain) ThenFunc(fn http.HandlerFunc) http.Handler {
+	if fn == nil {
+		return c.Then(nil)
+	}
+	return c.Then(fn)
+}
+
+// Append extends a Chain, returning a new Chain without mutating the
+// receiver, so a base chain can be reused across multiple routes.
+func (c Chain) Append(constructors ...Constructor) Chain {
+	newCons := make([]Constructor, 0, len(c.constructors)+len(constructors))
+	newCons = append(newCons, c.constructors...)
+	newCons = append(newCons, constructors...)
+	return New(newCons...)
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger is the structured logging interface consumed by LoggingMiddleware.
+// It is intentionally minimal so that zerolog, zap, logrus, etc. can be
+// adapted to it with a thin wrapper rather than forcing a dependency on any
+// one of them.
+type Logger interface {
+	// Log emits a single structured record. fields is a flat key/value map
+	// (method, path, status, latency, request_id, remote_ip, ...).
+	Log(fields map[string]interface{})
+}
+
+// statusWriter wraps an http.ResponseWriter to record the status code that
+// was sent, since the standard library gives no way to read it back.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// LoggingMiddleware returns a Constructor that logs one structured record
+// per request: method, path, status, latency, request ID, and remote IP.
+func LoggingMiddleware(logger Logger) Constructor {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			logger.Log(map[string]interface{}{
+				"method":     r.Method,
+				"path":       r.URL.Path,
+				"status":     sw.status,
+				"latency_ms": time.Since(start).Milliseconds(),
+				"request_id": RequestIDFromContext(r.Context()),
+				"remote_ip":  r.RemoteAddr,
+			})
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/Shishircipher/wecollabo/config"
+	"github.com/Shishircipher/wecollabo/notifier"
+)
+
+// devErrorPage renders the recovered error and its stack trace so it's
+// visible straight in the browser during local development.
+var devErrorPage = template.Must(template.New("panic").Parse(`<!DOCTYPE html>
+<html>
+<head><title>500 Internal Server Error</title></head>
+<body>
+<h1>Internal Server Error</h1>
+<p><strong>Request ID:</strong> {{.RequestID}}</p>
+<p><strong>Error:</strong> {{.Error}}</p>
+<pre>{{.Stack}}</pre>
+</body>
+</html>
+`))
+
+type devErrorPageData struct {
+	RequestID string
+	Error     string
+	Stack     string
+}
+
+// RecoveryOptions configures Recovery.
+type RecoveryOptions struct {
+	// Logger receives one structured record per recovered panic.
+	Logger Logger
+	// Environment controls what the client sees: Development renders the
+	// error and stack trace as HTML, Production sends a generic message.
+	Environment config.Environment
+	// FilterRuntimeFrames, when true, strips stack frames that belong to
+	// the Go runtime or vendored dependencies from the HTML page so only
+	// application frames are shown. The full stack is always logged
+	// server-side regardless of this setting.
+	FilterRuntimeFrames bool
+	// Dispatcher, if set, fans each recovered panic out to the configured
+	// PanicNotifiers (Slack, email, Sentry, ...) asynchronously.
+	Dispatcher *notifier.Dispatcher
+}
+
+// Recovery returns a Constructor that runs the chain behind a
+// BufferedResponseWriter (see buffer.go) so a panic never leaks a partial
+// response, logs the recovered panic with its request ID, and renders a
+// dev-friendly HTML page or a generic message depending on opts.Environment.
+func Recovery(opts RecoveryOptions) Constructor {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bw := NewBufferedResponseWriter(w)
+			defer func() {
+				err := recover()
+				if err == nil {
+					bw.FlushTo(w)
+					return
+				}
+
+				var buf bytes.Buffer
+				pprof.Lookup("goroutine").WriteTo(&buf, 2) // Capture stack trace
+				stack := buf.String()
+				requestID := RequestIDFromContext(r.Context())
+
+				if opts.Logger != nil {
+					opts.Logger.Log(map[string]interface{}{
+						"request_id": requestID,
+						"error":      err,
+						"stack":      stack,
+					})
+				}
+
+				if opts.Dispatcher != nil {
+					opts.Dispatcher.Dispatch(r.Context(), notifier.PanicEvent{
+						Error:     fmtError(err),
+						Stack:     stack,
+						Method:    r.Method,
+						Path:      r.URL.Path,
+						Headers:   map[string][]string(r.Header),
+						RequestID: requestID,
+						Timestamp: time.Now(),
+					})
+				}
+
+				if bw.Flushed() {
+					// The client already received bytes; there's nothing
+					// left to discard, just report what happened above.
+					return
+				}
+				bw.Discard()
+
+				if opts.Environment == config.Development {
+					displayStack := stack
+					if opts.FilterRuntimeFrames {
+						displayStack = filterRuntimeFrames(stack)
+					}
+					w.Header().Set("Content-Type", "text/html; charset=utf-8")
+					w.WriteHeader(http.StatusInternalServerError)
+					devErrorPage.Execute(w, devErrorPageData{
+						RequestID: requestID,
+						Error:     fmtError(err),
+						Stack:     displayStack,
+					})
+					return
+				}
+
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			}()
+			next.ServeHTTP(bw, r)
+		})
+	}
+}
+
+func fmtError(err interface{}) string {
+	if e, ok := err.(error); ok {
+		return e.Error()
+	}
+	if s, ok := err.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", err)
+}
+
+// filterRuntimeFrames drops goroutine-dump frames that originate from the
+// Go runtime or vendored packages, leaving only application-level frames.
+//
+// A frame in a runtime/pprof goroutine dump is always two lines: a
+// function line, then an indented "\tfile:line +offset" line. A builtin
+// call like panic(...) has an unqualified function line, so membership is
+// judged from the file line, which always carries the real source path;
+// the two lines are then dropped (or kept) as a pair so a filtered-out
+// frame never leaves its file:line dangling under the next frame's
+// function name.
+func filterRuntimeFrames(stack string) string {
+	lines := strings.Split(stack, "\n")
+	kept := make([]string, 0, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" || strings.HasPrefix(line, "goroutine ") {
+			kept = append(kept, line)
+			continue
+		}
+
+		var fileLine string
+		if i+1 < len(lines) {
+			fileLine = lines[i+1]
+		}
+		if isRuntimeOrVendorFrame(fileLine) {
+			i++ // also consume the file:line half of this frame
+			continue
+		}
+
+		kept = append(kept, line)
+		if fileLine != "" {
+			kept = append(kept, fileLine)
+			i++
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+func isRuntimeOrVendorFrame(fileLine string) bool {
+	return strings.Contains(fileLine, "/runtime/") || strings.Contains(fileLine, "/vendor/")
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFilterRuntimeFrames_KeepsCallerAfterBuiltinPanicFrame reproduces a
+// real recovered-panic dump: the panic(...) frame's function line has no
+// package prefix, so its runtime membership can only be judged from its
+// file line. Filtering must not let that swallow the next (application)
+// frame's function line.
+func TestFilterRuntimeFrames_KeepsCallerAfterBuiltinPanicFrame(t *testing.T) {
+	stack := "goroutine 21 [running]:\n" +
+		"panic({0x4b8100?, 0x4f47f0?})\n" +
+		"\t/usr/local/go/src/runtime/panic.go:914 +0x21f\n" +
+		"main.inner(...)\n" +
+		"\t/app/main.go:53 +0x25\n" +
+		"main.main()\n" +
+		"\t/app/main.go:10 +0x10\n"
+
+	got := filterRuntimeFrames(stack)
+
+	if want := "panic.go:914"; strings.Contains(got, want) {
+		t.Fatalf("filtered stack still contains runtime frame %q:\n%s", want, got)
+	}
+	if want := "main.inner(...)"; !strings.Contains(got, want) {
+		t.Fatalf("filtered stack dropped caller frame %q:\n%s", want, got)
+	}
+	if want := "/app/main.go:53"; !strings.Contains(got, want) {
+		t.Fatalf("filtered stack dropped caller's file:line %q:\n%s", want, got)
+	}
+}
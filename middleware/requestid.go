@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header used to propagate the request ID to the
+// client and to any downstream services the request is forwarded to.
+const RequestIDHeader = "X-Request-ID"
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// RequestIDMiddleware injects a unique request ID into the request context
+// and echoes it back on the response via the X-Request-ID header. If the
+// caller already supplied a request ID, it is reused so traces stay
+// consistent across service boundaries.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if none
+// was set (for example, when the handler isn't wrapped in
+// RequestIDMiddleware).
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand failing is effectively unrecoverable; fall back to a
+		// fixed placeholder rather than panicking inside a middleware.
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the settings needed to build the notifiers used by a
+// Dispatcher. It loads from plain environment variables so it has no
+// dependency beyond the standard library; a project already using viper
+// can populate the same struct from its own config tree instead of calling
+// LoadConfigFromEnv.
+type Config struct {
+	SlackWebhookURL string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPFrom     string
+	SMTPTo       []string
+	SMTPUsername string
+	SMTPPassword string
+
+	SentryDSN string
+
+	DedupWindow     time.Duration
+	RateLimit       int
+	RateLimitWindow time.Duration
+}
+
+// LoadConfigFromEnv reads notifier configuration from environment
+// variables, leaving any unset channel disabled.
+func LoadConfigFromEnv() Config {
+	cfg := Config{
+		SlackWebhookURL: os.Getenv("NOTIFIER_SLACK_WEBHOOK_URL"),
+		SMTPHost:        os.Getenv("NOTIFIER_SMTP_HOST"),
+		SMTPFrom:        os.Getenv("NOTIFIER_SMTP_FROM"),
+		SMTPUsername:    os.Getenv("NOTIFIER_SMTP_USERNAME"),
+		SMTPPassword:    os.Getenv("NOTIFIER_SMTP_PASSWORD"),
+		SentryDSN:       os.Getenv("NOTIFIER_SENTRY_DSN"),
+		DedupWindow:     5 * time.Minute,
+		RateLimit:       10,
+		RateLimitWindow: time.Minute,
+	}
+
+	if port, err := strconv.Atoi(os.Getenv("NOTIFIER_SMTP_PORT")); err == nil {
+		cfg.SMTPPort = port
+	}
+	if to := os.Getenv("NOTIFIER_SMTP_TO"); to != "" {
+		cfg.SMTPTo = strings.Split(to, ",")
+	}
+
+	return cfg
+}
+
+// Build constructs a Dispatcher from cfg, including only the notifiers
+// whose configuration was actually supplied.
+func (cfg Config) Build() *Dispatcher {
+	var notifiers []PanicNotifier
+
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(cfg.SlackWebhookURL))
+	}
+	if cfg.SMTPHost != "" && len(cfg.SMTPTo) > 0 {
+		var auth smtp.Auth
+		if cfg.SMTPUsername != "" {
+			// PLAIN auth is what every mainstream relay (Gmail, SES,
+			// SendGrid, ...) expects over the implicit TLS connection
+			// net/smtp negotiates via STARTTLS. Without credentials this
+			// only works against an open relay.
+			auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+		}
+		notifiers = append(notifiers, &EmailNotifier{
+			Host: cfg.SMTPHost,
+			Port: cfg.SMTPPort,
+			From: cfg.SMTPFrom,
+			To:   cfg.SMTPTo,
+			Auth: auth,
+		})
+	}
+	if cfg.SentryDSN != "" {
+		if n, err := NewSentryNotifier(cfg.SentryDSN); err == nil {
+			notifiers = append(notifiers, n)
+		}
+	}
+
+	return &Dispatcher{
+		Notifiers:       notifiers,
+		DedupWindow:     cfg.DedupWindow,
+		RateLimit:       cfg.RateLimit,
+		RateLimitWindow: cfg.RateLimitWindow,
+		Timeout:         10 * time.Second,
+	}
+}
@@ -0,0 +1,110 @@
+package notifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Dispatcher fans a single PanicEvent out to a list of PanicNotifiers
+// asynchronously, with rate limiting and deduplication so a burst of
+// identical panics (e.g. a hot loop hitting the same bug) doesn't flood
+// Slack, email, or Sentry with duplicate alerts.
+type Dispatcher struct {
+	Notifiers []PanicNotifier
+
+	// DedupWindow suppresses repeat notifications for the same error+stack
+	// hash within this window. Zero disables deduplication.
+	DedupWindow time.Duration
+	// RateLimit caps how many distinct notifications are dispatched within
+	// RateLimitWindow. Zero disables rate limiting.
+	RateLimit       int
+	RateLimitWindow time.Duration
+
+	// Timeout bounds each notifier's Notify call. Zero means no timeout
+	// beyond the caller's context.
+	Timeout time.Duration
+
+	mu          sync.Mutex
+	lastSeen    map[string]time.Time
+	windowStart time.Time
+	windowCount int
+}
+
+// Dispatch decides whether event should be delivered (applying
+// deduplication and rate limiting) and, if so, notifies every configured
+// PanicNotifier concurrently. It never blocks the caller on the notifiers'
+// network calls.
+func (d *Dispatcher) Dispatch(ctx context.Context, event PanicEvent) {
+	if !d.admit(event) {
+		return
+	}
+
+	for _, n := range d.Notifiers {
+		n := n
+		go func() {
+			// Dispatch is fire-and-forget: the caller's ctx belongs to the
+			// in-flight request and net/http cancels it as soon as the
+			// handler returns, which would drop every notification almost
+			// immediately. Notifiers always get a context derived from
+			// Background instead.
+			notifyCtx := context.Background()
+			if d.Timeout > 0 {
+				var cancel context.CancelFunc
+				notifyCtx, cancel = context.WithTimeout(notifyCtx, d.Timeout)
+				defer cancel()
+			}
+			// Best-effort delivery: a failed notifier shouldn't take down
+			// the request or the other notifiers, so errors are dropped
+			// here rather than propagated.
+			_ = n.Notify(notifyCtx, event)
+		}()
+	}
+}
+
+// admit reports whether event passes deduplication and rate limiting, and
+// records its bookkeeping if so.
+func (d *Dispatcher) admit(event PanicEvent) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := event.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if d.DedupWindow > 0 {
+		if d.lastSeen == nil {
+			d.lastSeen = make(map[string]time.Time)
+		}
+		key := dedupKey(event)
+		if last, ok := d.lastSeen[key]; ok && now.Sub(last) < d.DedupWindow {
+			return false
+		}
+		d.lastSeen[key] = now
+	}
+
+	if d.RateLimit > 0 && d.RateLimitWindow > 0 {
+		if d.windowStart.IsZero() || now.Sub(d.windowStart) >= d.RateLimitWindow {
+			d.windowStart = now
+			d.windowCount = 0
+		}
+		if d.windowCount >= d.RateLimit {
+			return false
+		}
+		d.windowCount++
+	}
+
+	return true
+}
+
+// dedupKey hashes the error message and stack trace so two panics with the
+// same root cause collapse to the same key regardless of request path.
+func dedupKey(event PanicEvent) string {
+	h := sha256.New()
+	h.Write([]byte(event.Error))
+	h.Write([]byte(event.Stack))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,136 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingNotifier struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingNotifier) Notify(ctx context.Context, event PanicEvent) error {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *countingNotifier) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestDispatcher_DedupDropsRepeatedPanicWithinWindow(t *testing.T) {
+	n := &countingNotifier{}
+	d := &Dispatcher{Notifiers: []PanicNotifier{n}, DedupWindow: time.Minute}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := PanicEvent{Error: "boom", Stack: "same stack", Timestamp: base}
+
+	d.Dispatch(context.Background(), event)
+	event.Timestamp = base.Add(10 * time.Second) // same hash, still inside window
+	d.Dispatch(context.Background(), event)
+
+	waitForCalls(t, n, 1)
+}
+
+func TestDispatcher_DedupAllowsRepeatAfterWindowExpires(t *testing.T) {
+	n := &countingNotifier{}
+	d := &Dispatcher{Notifiers: []PanicNotifier{n}, DedupWindow: time.Minute}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := PanicEvent{Error: "boom", Stack: "same stack", Timestamp: base}
+
+	d.Dispatch(context.Background(), event)
+	event.Timestamp = base.Add(2 * time.Minute) // past DedupWindow
+	d.Dispatch(context.Background(), event)
+
+	waitForCalls(t, n, 2)
+}
+
+func TestDispatcher_RateLimitCapsNotificationsPerWindow(t *testing.T) {
+	n := &countingNotifier{}
+	d := &Dispatcher{
+		Notifiers:       []PanicNotifier{n},
+		RateLimit:       2,
+		RateLimitWindow: time.Minute,
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		d.Dispatch(context.Background(), PanicEvent{
+			Error:     "boom",
+			Stack:     fmt.Sprintf("distinct stack %d", i), // DedupWindow is unset here; vary the stack anyway so this test only exercises rate limiting.
+			Timestamp: base,
+		})
+		base = base.Add(time.Second)
+	}
+
+	waitForCalls(t, n, 2)
+}
+
+type ctxCheckingNotifier struct {
+	mu       sync.Mutex
+	sawLive  bool
+	notified bool
+}
+
+func (c *ctxCheckingNotifier) Notify(ctx context.Context, event PanicEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notified = true
+	c.sawLive = ctx.Err() == nil
+	return nil
+}
+
+func (c *ctxCheckingNotifier) result() (notified, sawLive bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.notified, c.sawLive
+}
+
+func TestDispatcher_SurvivesCallerContextCancellation(t *testing.T) {
+	n := &ctxCheckingNotifier{}
+	d := &Dispatcher{Notifiers: []PanicNotifier{n}}
+
+	// Dispatch is fire-and-forget from an HTTP handler's perspective: the
+	// request context is canceled as soon as the handler returns, which
+	// must not stop the notifier from still firing.
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Dispatch(ctx, PanicEvent{Error: "boom", Stack: "some stack"})
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if notified, _ := n.result(); notified {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	notified, sawLive := n.result()
+	if !notified {
+		t.Fatal("notifier was never called")
+	}
+	if !sawLive {
+		t.Fatal("notifier received an already-canceled context derived from the caller's request context")
+	}
+}
+
+func waitForCalls(t *testing.T, n *countingNotifier, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if n.count() == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("got %d notifier calls, want %d", n.count(), want)
+}
@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+)
+
+// EmailNotifier sends a recovered panic as a plain-text email over SMTP.
+type EmailNotifier struct {
+	Host string
+	Port int
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// Notify sends an email describing event. SMTP has no notion of context
+// cancellation, so ctx is only checked before dialing.
+func (e *EmailNotifier) Notify(ctx context.Context, event PanicEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Subject: [panic] %s %s\r\n", event.Method, event.Path)
+	body := fmt.Sprintf("Request ID: %s\r\nTime: %s\r\n\r\n%s\r\n\r\nStack trace:\r\n%s\r\n",
+		event.RequestID, event.Timestamp.Format("2006-01-02T15:04:05Z07:00"), event.Error, event.Stack)
+	msg := []byte(subject + "\r\n" + body)
+
+	addr := fmt.Sprintf("%s:%d", e.Host, e.Port)
+	return smtp.SendMail(addr, e.Auth, e.From, e.To, msg)
+}
@@ -0,0 +1,26 @@
+// Package notifier fans out recovered panics to external alerting
+// channels (Slack, email, Sentry, ...).
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// PanicEvent describes a single recovered panic, with enough detail for a
+// notifier to build a useful alert.
+type PanicEvent struct {
+	Error     string
+	Stack     string
+	Method    string
+	Path      string
+	Headers   map[string][]string
+	RequestID string
+	Timestamp time.Time
+}
+
+// PanicNotifier delivers a PanicEvent to an external system. Implementations
+// should treat ctx's deadline as a hard budget for the network call.
+type PanicNotifier interface {
+	Notify(ctx context.Context, event PanicEvent) error
+}
@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SentryNotifier posts events to Sentry's HTTP store endpoint using a raw
+// DSN, so it has no dependency on the official sentry-go SDK.
+type SentryNotifier struct {
+	DSN    string
+	Client *http.Client
+}
+
+// NewSentryNotifier parses dsn (e.g. "https://<key>@<host>/<project>") and
+// returns a ready-to-use SentryNotifier, or an error if the DSN is
+// malformed.
+func NewSentryNotifier(dsn string) (*SentryNotifier, error) {
+	if _, err := parseSentryDSN(dsn); err != nil {
+		return nil, err
+	}
+	return &SentryNotifier{DSN: dsn, Client: http.DefaultClient}, nil
+}
+
+type sentryEndpoint struct {
+	storeURL string
+	key      string
+}
+
+// parseSentryDSN turns a Sentry DSN into the store API URL and public key
+// expected by the X-Sentry-Auth header.
+func parseSentryDSN(dsn string) (sentryEndpoint, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return sentryEndpoint{}, fmt.Errorf("notifier: invalid sentry DSN: %w", err)
+	}
+	if u.User == nil {
+		return sentryEndpoint{}, fmt.Errorf("notifier: sentry DSN missing public key")
+	}
+
+	projectID := strings.Trim(u.Path, "/")
+	if projectID == "" {
+		return sentryEndpoint{}, fmt.Errorf("notifier: sentry DSN missing project id")
+	}
+
+	store := url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   fmt.Sprintf("/api/%s/store/", projectID),
+	}
+	return sentryEndpoint{storeURL: store.String(), key: u.User.Username()}, nil
+}
+
+type sentryEnvelope struct {
+	Message   string            `json:"message"`
+	Level     string            `json:"level"`
+	Extra     map[string]string `json:"extra"`
+	Exception []sentryException `json:"exception,omitempty"`
+	Timestamp string            `json:"timestamp"`
+}
+
+type sentryException struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Notify posts event to the Sentry project addressed by the DSN.
+func (s *SentryNotifier) Notify(ctx context.Context, event PanicEvent) error {
+	endpoint, err := parseSentryDSN(s.DSN)
+	if err != nil {
+		return err
+	}
+
+	payload := sentryEnvelope{
+		Message: event.Error,
+		Level:   "fatal",
+		Extra: map[string]string{
+			"request_id": event.RequestID,
+			"method":     event.Method,
+			"path":       event.Path,
+			"stack":      event.Stack,
+		},
+		Exception: []sentryException{{Type: "panic", Value: event.Error}},
+		Timestamp: event.Timestamp.UTC().Format("2006-01-02T15:04:05"),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s", endpoint.key))
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry store endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
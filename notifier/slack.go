@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SlackNotifier posts a recovered panic to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewSlackNotifier builds a SlackNotifier with a default HTTP client.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{WebhookURL: webhookURL, Client: http.DefaultClient}
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// Notify posts a formatted summary of event to the configured webhook.
+func (s *SlackNotifier) Notify(ctx context.Context, event PanicEvent) error {
+	text := fmt.Sprintf(":rotating_light: panic on %s %s (request_id=%s)\n```%s```",
+		event.Method, event.Path, event.RequestID, event.Error)
+
+	body, err := json.Marshal(slackPayload{Text: text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}